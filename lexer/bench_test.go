@@ -0,0 +1,48 @@
+package lexer
+
+import (
+	"strings"
+	"testing"
+
+	"monkey-interpreter/token"
+)
+
+// program returns a synthetic Monkey program of roughly n statements, used
+// to exercise both lexer input paths at multi-MB scale.
+func program(n int) string {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		sb.WriteString(`let result = add(five, ten) + arr[1] * "hello world";` + "\n")
+	}
+	return sb.String()
+}
+
+func drain(lex *Lexer) {
+	for {
+		if tok := lex.NextToken(); tok.Type == token.EOF {
+			return
+		}
+	}
+}
+
+func BenchmarkNextTokenString(b *testing.B) {
+	input := program(50000)
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		drain(New(input))
+	}
+}
+
+func BenchmarkNextTokenReader(b *testing.B) {
+	input := program(50000)
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		drain(NewReader(strings.NewReader(input)))
+	}
+}
@@ -1,36 +1,80 @@
 package lexer
 
-import "monkey-interpreter/token"
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+
+	"monkey-interpreter/token"
+)
 
 type Lexer struct {
-	input        string
-	position     int  // Current position in input
-	readPosition int  // Current reading positon in input
-	ch           byte // Current char under examination
+	src    source
+	offset int  // Offset of ch in the input
+	ch     byte // Current char under examination
+
+	line   int // Current 1-indexed line number
+	column int // Current 1-indexed column number
 }
 
 func New(input string) *Lexer {
-	lex := &Lexer{input: input}
+	return newLexer(newStringSource(input))
+}
+
+// NewReader builds a Lexer that pulls its input from r as needed, via a
+// buffered reader, rather than requiring the whole program up front.
+func NewReader(r io.Reader) *Lexer {
+	return newLexer(newReaderSource(bufio.NewReader(r)))
+}
+
+func newLexer(src source) *Lexer {
+	lex := &Lexer{src: src, line: 1, offset: -1}
 	lex.readChar()
 	return lex
 }
 
 func (lex *Lexer) readChar() {
-	if lex.readPosition >= len(lex.input) { // End of input
-		lex.ch = 0
-	} else {
-		lex.ch = lex.input[lex.readPosition]
+	if lex.ch == '\n' {
+		lex.line++
+		lex.column = 0
 	}
-	lex.position = lex.readPosition
-	lex.readPosition += 1
+
+	lex.ch = lex.src.next()
+	lex.offset++
+	lex.column++
+}
+
+// peekChar returns the byte after the current one without advancing the
+// lexer's position.
+func (lex *Lexer) peekChar() byte {
+	return lex.src.peek()
 }
 
 func (lex *Lexer) NextToken() token.Token {
 	var tok token.Token
 
+	lex.skipWhitespace()
+
+	startLine, startColumn, startOffset := lex.line, lex.column, lex.offset
+
 	switch lex.ch {
 	case '=':
-		tok = newToken(token.ASSIGN, lex.ch)
+		if lex.peekChar() == '=' {
+			ch := lex.ch
+			lex.readChar()
+			tok = token.Token{Type: token.EQ, Literal: string(ch) + string(lex.ch)}
+		} else {
+			tok = newToken(token.ASSIGN, lex.ch)
+		}
+	case '!':
+		if lex.peekChar() == '=' {
+			ch := lex.ch
+			lex.readChar()
+			tok = token.Token{Type: token.NOT_EQ, Literal: string(ch) + string(lex.ch)}
+		} else {
+			tok = newToken(token.BANG, lex.ch)
+		}
 	case ';':
 		tok = newToken(token.SEMICOLON, lex.ch)
 	case '(':
@@ -41,19 +85,192 @@ func (lex *Lexer) NextToken() token.Token {
 		tok = newToken(token.COMMA, lex.ch)
 	case '+':
 		tok = newToken(token.PLUS, lex.ch)
+	case '-':
+		tok = newToken(token.MINUS, lex.ch)
+	case '*':
+		tok = newToken(token.ASTERISK, lex.ch)
+	case '/':
+		tok = newToken(token.SLASH, lex.ch)
+	case '<':
+		tok = newToken(token.LT, lex.ch)
+	case '>':
+		tok = newToken(token.GT, lex.ch)
 	case '{':
 		tok = newToken(token.LBRACE, lex.ch)
 	case '}':
 		tok = newToken(token.RBRACE, lex.ch)
+	case '[':
+		tok = newToken(token.LBRACKET, lex.ch)
+	case ']':
+		tok = newToken(token.RBRACKET, lex.ch)
+	case ':':
+		tok = newToken(token.COLON, lex.ch)
+	case '"':
+		literal, ok := lex.readString()
+		tok.Literal = literal
+		if ok {
+			tok.Type = token.STRING
+		} else {
+			tok.Type = token.ILLEGAL
+		}
 	case 0:
 		tok.Literal = ""
 		tok.Type = token.EOF
+	default:
+		if isLetter(lex.ch) {
+			tok.Literal = lex.readIdentifier()
+			tok.Type = token.LookupIdent(tok.Literal)
+			tok.Line, tok.Column, tok.Offset = startLine, startColumn, startOffset
+			return tok
+		} else if isDigit(lex.ch) {
+			tok.Type = token.INT
+			tok.Literal = lex.readNumber()
+			tok.Line, tok.Column, tok.Offset = startLine, startColumn, startOffset
+			return tok
+		} else {
+			tok = newToken(token.ILLEGAL, lex.ch)
+		}
 	}
 
 	lex.readChar()
+	tok.Line, tok.Column, tok.Offset = startLine, startColumn, startOffset
 	return tok
 }
 
+// Tokens streams the Lexer's output on a channel, terminating with an EOF
+// token and then closing the channel. It lets pipelined consumers
+// (formatters, syntax highlighters, language-server tooling) start working
+// before the whole program has been scanned. The channel is closed early,
+// without an EOF token, if ctx is cancelled first.
+func (lex *Lexer) Tokens(ctx context.Context) <-chan token.Token {
+	ch := make(chan token.Token)
+
+	go func() {
+		defer close(ch)
+
+		for {
+			tok := lex.NextToken()
+
+			select {
+			case ch <- tok:
+			case <-ctx.Done():
+				return
+			}
+
+			if tok.Type == token.EOF {
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// skipWhitespace advances past any spaces, tabs, newlines, and carriage
+// returns preceding the next token.
+func (lex *Lexer) skipWhitespace() {
+	for lex.ch == ' ' || lex.ch == '\t' || lex.ch == '\n' || lex.ch == '\r' {
+		lex.readChar()
+	}
+}
+
+// readString consumes a double-quoted string literal, starting after the
+// opening quote, and returns its contents with escape sequences resolved
+// along with whether the string was properly closed. Supported escapes are
+// \n, \t, \" and \\. Reaching EOF before the closing quote - including EOF
+// right after a trailing backslash, which has nothing left to escape -
+// reports ok=false instead of returning a literal with a partial or bogus
+// escape baked into it.
+func (lex *Lexer) readString() (literal string, ok bool) {
+	var out strings.Builder
+
+	for {
+		lex.readChar()
+
+		if lex.ch == '\\' {
+			if lex.peekChar() == 0 {
+				return out.String(), false
+			}
+
+			switch lex.peekChar() {
+			case 'n':
+				out.WriteByte('\n')
+			case 't':
+				out.WriteByte('\t')
+			case '"':
+				out.WriteByte('"')
+			case '\\':
+				out.WriteByte('\\')
+			default:
+				out.WriteByte('\\')
+				out.WriteByte(lex.peekChar())
+			}
+			lex.readChar()
+			continue
+		}
+
+		if lex.ch == '"' {
+			return out.String(), true
+		}
+
+		if lex.ch == 0 {
+			return out.String(), false
+		}
+
+		out.WriteByte(lex.ch)
+	}
+}
+
+// readIdentifier consumes and returns a run of letters starting at the
+// current position. On a rangeSource (the string-backed path), this slices
+// the already-buffered input instead of rebuilding it byte by byte.
+func (lex *Lexer) readIdentifier() string {
+	if rs, ok := lex.src.(rangeSource); ok {
+		start := lex.offset
+		for isLetter(lex.ch) {
+			lex.readChar()
+		}
+		return rs.slice(start, lex.offset)
+	}
+
+	var out strings.Builder
+	for isLetter(lex.ch) {
+		out.WriteByte(lex.ch)
+		lex.readChar()
+	}
+	return out.String()
+}
+
+// readNumber consumes and returns a run of digits starting at the current
+// position. On a rangeSource (the string-backed path), this slices the
+// already-buffered input instead of rebuilding it byte by byte.
+func (lex *Lexer) readNumber() string {
+	if rs, ok := lex.src.(rangeSource); ok {
+		start := lex.offset
+		for isDigit(lex.ch) {
+			lex.readChar()
+		}
+		return rs.slice(start, lex.offset)
+	}
+
+	var out strings.Builder
+	for isDigit(lex.ch) {
+		out.WriteByte(lex.ch)
+		lex.readChar()
+	}
+	return out.String()
+}
+
+// isLetter reports whether ch can appear in an identifier.
+func isLetter(ch byte) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+}
+
+// isDigit reports whether ch is a decimal digit.
+func isDigit(ch byte) bool {
+	return '0' <= ch && ch <= '9'
+}
+
 func newToken(tokenType token.TokenType, ch byte) token.Token {
 	return token.Token{Type: tokenType, Literal: string(ch)}
 }
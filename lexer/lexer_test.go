@@ -0,0 +1,275 @@
+package lexer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"monkey-interpreter/token"
+)
+
+func TestNextToken(t *testing.T) {
+	input := `let five = 5;
+let ten = 10;
+
+let add = fn(x, y) {
+  x + y;
+};
+
+let result = add(five, ten);
+!-/*5;
+5 < 10 > 5;
+
+if (5 < 10) {
+	return true;
+} else {
+	return false;
+}
+
+10 == 10;
+10 != 9;
+`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LET, "let"},
+		{token.IDENT, "five"},
+		{token.ASSIGN, "="},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.LET, "let"},
+		{token.IDENT, "ten"},
+		{token.ASSIGN, "="},
+		{token.INT, "10"},
+		{token.SEMICOLON, ";"},
+		{token.LET, "let"},
+		{token.IDENT, "add"},
+		{token.ASSIGN, "="},
+		{token.FUNCTION, "fn"},
+		{token.LPAREN, "("},
+		{token.IDENT, "x"},
+		{token.COMMA, ","},
+		{token.IDENT, "y"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.IDENT, "x"},
+		{token.PLUS, "+"},
+		{token.IDENT, "y"},
+		{token.SEMICOLON, ";"},
+		{token.RBRACE, "}"},
+		{token.SEMICOLON, ";"},
+		{token.LET, "let"},
+		{token.IDENT, "result"},
+		{token.ASSIGN, "="},
+		{token.IDENT, "add"},
+		{token.LPAREN, "("},
+		{token.IDENT, "five"},
+		{token.COMMA, ","},
+		{token.IDENT, "ten"},
+		{token.RPAREN, ")"},
+		{token.SEMICOLON, ";"},
+		{token.BANG, "!"},
+		{token.MINUS, "-"},
+		{token.SLASH, "/"},
+		{token.ASTERISK, "*"},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.INT, "5"},
+		{token.LT, "<"},
+		{token.INT, "10"},
+		{token.GT, ">"},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.IF, "if"},
+		{token.LPAREN, "("},
+		{token.INT, "5"},
+		{token.LT, "<"},
+		{token.INT, "10"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.RETURN, "return"},
+		{token.TRUE, "true"},
+		{token.SEMICOLON, ";"},
+		{token.RBRACE, "}"},
+		{token.ELSE, "else"},
+		{token.LBRACE, "{"},
+		{token.RETURN, "return"},
+		{token.FALSE, "false"},
+		{token.SEMICOLON, ";"},
+		{token.RBRACE, "}"},
+		{token.INT, "10"},
+		{token.EQ, "=="},
+		{token.INT, "10"},
+		{token.SEMICOLON, ";"},
+		{token.INT, "10"},
+		{token.NOT_EQ, "!="},
+		{token.INT, "9"},
+		{token.SEMICOLON, ";"},
+		{token.EOF, ""},
+	}
+
+	lex := New(input)
+
+	for i, tt := range tests {
+		tok := lex.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNewReaderMatchesNew(t *testing.T) {
+	input := `let add = fn(x, y) { x + y; };`
+
+	strLex := New(input)
+	readerLex := NewReader(strings.NewReader(input))
+
+	for {
+		want := strLex.NextToken()
+		got := readerLex.NextToken()
+
+		if got.Type != want.Type || got.Literal != want.Literal {
+			t.Fatalf("NewReader token mismatch: got=%+v, want=%+v", got, want)
+		}
+
+		if want.Type == token.EOF {
+			break
+		}
+	}
+}
+
+func TestNextTokenStringsArraysAndHashes(t *testing.T) {
+	input := `"foobar"
+"foo bar"
+[1, 2];
+{"foo": "bar"}
+`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.STRING, "foobar"},
+		{token.STRING, "foo bar"},
+		{token.LBRACKET, "["},
+		{token.INT, "1"},
+		{token.COMMA, ","},
+		{token.INT, "2"},
+		{token.RBRACKET, "]"},
+		{token.SEMICOLON, ";"},
+		{token.LBRACE, "{"},
+		{token.STRING, "foo"},
+		{token.COLON, ":"},
+		{token.STRING, "bar"},
+		{token.RBRACE, "}"},
+		{token.EOF, ""},
+	}
+
+	lex := New(input)
+
+	for i, tt := range tests {
+		tok := lex.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenStringEscapes(t *testing.T) {
+	input := `"foo\nbar\t\"baz\"\\qux"`
+
+	lex := New(input)
+	tok := lex.NextToken()
+
+	if tok.Type != token.STRING {
+		t.Fatalf("tok.Type wrong. expected=%q, got=%q", token.STRING, tok.Type)
+	}
+
+	expected := "foo\nbar\t\"baz\"\\qux"
+	if tok.Literal != expected {
+		t.Fatalf("tok.Literal wrong. expected=%q, got=%q", expected, tok.Literal)
+	}
+}
+
+func TestNextTokenUnterminatedStringEscape(t *testing.T) {
+	input := "\"foo\\"
+
+	lex := New(input)
+	tok := lex.NextToken()
+
+	if tok.Type != token.ILLEGAL {
+		t.Fatalf("tok.Type wrong. expected=%q, got=%q", token.ILLEGAL, tok.Type)
+	}
+
+	if tok.Literal != "foo" {
+		t.Fatalf("tok.Literal wrong. expected=%q, got=%q", "foo", tok.Literal)
+	}
+}
+
+func TestTokenPositions(t *testing.T) {
+	input := "let x = 5;\nlet y = 10;"
+
+	tests := []struct {
+		expectedType   token.TokenType
+		expectedLine   int
+		expectedColumn int
+	}{
+		{token.LET, 1, 1},
+		{token.IDENT, 1, 5},
+		{token.ASSIGN, 1, 7},
+		{token.INT, 1, 9},
+		{token.SEMICOLON, 1, 10},
+		{token.LET, 2, 1},
+		{token.IDENT, 2, 5},
+	}
+
+	lex := New(input)
+
+	for i, tt := range tests {
+		tok := lex.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Line != tt.expectedLine {
+			t.Fatalf("tests[%d] - line wrong. expected=%d, got=%d", i, tt.expectedLine, tok.Line)
+		}
+
+		if tok.Column != tt.expectedColumn {
+			t.Fatalf("tests[%d] - column wrong. expected=%d, got=%d", i, tt.expectedColumn, tok.Column)
+		}
+	}
+}
+
+func TestTokensChannel(t *testing.T) {
+	input := `let x = 5;`
+	lex := New(input)
+
+	var got []token.Token
+	for tok := range lex.Tokens(context.Background()) {
+		got = append(got, tok)
+	}
+
+	if len(got) == 0 || got[len(got)-1].Type != token.EOF {
+		t.Fatalf("Tokens channel did not end with EOF, got %+v", got)
+	}
+
+	if got[0].Type != token.LET {
+		t.Fatalf("Tokens channel first token wrong. got=%+v", got[0])
+	}
+}
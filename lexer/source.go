@@ -0,0 +1,79 @@
+package lexer
+
+import "bufio"
+
+// source supplies the raw bytes of a program to a Lexer, one at a time,
+// with a single byte of lookahead. It lets NextToken work the same way
+// whether the program started out as a string or as an io.Reader.
+type source interface {
+	// next consumes and returns the next byte, or 0 at end of input.
+	next() byte
+	// peek returns the next byte without consuming it, or 0 at end of input.
+	peek() byte
+}
+
+// rangeSource is implemented by sources that can hand back a zero-copy
+// slice of input already scanned, by absolute byte offset. readIdentifier
+// and readNumber use it to avoid building up the token byte-by-byte on
+// sources where the bytes are already sitting in one contiguous buffer.
+type rangeSource interface {
+	slice(start, end int) string
+}
+
+// stringSource is a source backed by an in-memory string.
+type stringSource struct {
+	input string
+	pos   int
+}
+
+func newStringSource(input string) *stringSource {
+	return &stringSource{input: input}
+}
+
+// slice returns input[start:end]. start and end are absolute byte offsets
+// into input, as tracked by Lexer.offset.
+func (s *stringSource) slice(start, end int) string {
+	return s.input[start:end]
+}
+
+func (s *stringSource) next() byte {
+	if s.pos >= len(s.input) {
+		return 0
+	}
+	b := s.input[s.pos]
+	s.pos++
+	return b
+}
+
+func (s *stringSource) peek() byte {
+	if s.pos >= len(s.input) {
+		return 0
+	}
+	return s.input[s.pos]
+}
+
+// readerSource is a source backed by a buffered io.Reader, so large
+// programs can be lexed without being read into memory up front.
+type readerSource struct {
+	r *bufio.Reader
+}
+
+func newReaderSource(r *bufio.Reader) *readerSource {
+	return &readerSource{r: r}
+}
+
+func (s *readerSource) next() byte {
+	b, err := s.r.ReadByte()
+	if err != nil {
+		return 0
+	}
+	return b
+}
+
+func (s *readerSource) peek() byte {
+	b, err := s.r.Peek(1)
+	if err != nil {
+		return 0
+	}
+	return b[0]
+}
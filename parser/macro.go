@@ -0,0 +1,241 @@
+package parser
+
+import "monkey-interpreter/ast"
+
+// MacroEnv holds the macro definitions extracted from a program by
+// DefineMacros, for later use by ExpandMacros.
+type MacroEnv struct {
+	macros map[string]*ast.MacroLiteral
+}
+
+// NewMacroEnv returns an empty MacroEnv.
+func NewMacroEnv() *MacroEnv {
+	return &MacroEnv{macros: make(map[string]*ast.MacroLiteral)}
+}
+
+// Set registers the macro literal bound to name.
+func (e *MacroEnv) Set(name string, macro *ast.MacroLiteral) {
+	e.macros[name] = macro
+}
+
+// Get looks up a previously registered macro by name.
+func (e *MacroEnv) Get(name string) (*ast.MacroLiteral, bool) {
+	macro, ok := e.macros[name]
+	return macro, ok
+}
+
+// DefineMacros walks program's top-level statements, moving every
+// `let <name> = macro(...) { ... };` binding into env and removing it from
+// the program, so ExpandMacros and the eventual evaluator never see them.
+func DefineMacros(program *ast.Program, env *MacroEnv) {
+	definitions := []int{}
+
+	for i, stmt := range program.Statements {
+		if !isMacroDefinition(stmt) {
+			continue
+		}
+
+		addMacro(stmt, env)
+		definitions = append(definitions, i)
+	}
+
+	for i := len(definitions) - 1; i >= 0; i-- {
+		idx := definitions[i]
+		program.Statements = append(program.Statements[:idx], program.Statements[idx+1:]...)
+	}
+}
+
+func isMacroDefinition(stmt ast.Statement) bool {
+	letStmt, ok := stmt.(*ast.LetStatement)
+	if !ok {
+		return false
+	}
+
+	_, ok = letStmt.Value.(*ast.MacroLiteral)
+	return ok
+}
+
+func addMacro(stmt ast.Statement, env *MacroEnv) {
+	letStmt := stmt.(*ast.LetStatement)
+	macroLit := letStmt.Value.(*ast.MacroLiteral)
+	env.Set(letStmt.Name.Value, macroLit)
+}
+
+// ExpandMacros walks program looking for calls to macros defined in env,
+// runs each macro body with its arguments bound (as Quote values) to the
+// macro's parameters, and splices the resulting quoted AST back in where
+// the call used to be.
+func ExpandMacros(program *ast.Program, env *MacroEnv) *ast.Program {
+	expanded := modify(program, func(node ast.Node) ast.Node {
+		call, ok := node.(*ast.CallExpression)
+		if !ok {
+			return node
+		}
+
+		ident, ok := call.Function.(*ast.Identifier)
+		if !ok {
+			return node
+		}
+
+		macro, ok := env.Get(ident.Value)
+		if !ok {
+			return node
+		}
+
+		args := quoteArgs(call)
+		macroEnv := extendMacroEnv(macro, args)
+
+		evaluated := evalMacroBody(macro, macroEnv)
+
+		quote, ok := evaluated.(*ast.Quote)
+		if !ok {
+			panic("macros must return an AST node quoted with quote(...)")
+		}
+
+		return quote.Node
+	})
+
+	return expanded.(*ast.Program)
+}
+
+// quoteArgs wraps each of a macro call's arguments as a Quote, so the
+// macro body can splice them in verbatim via unquote(...).
+func quoteArgs(call *ast.CallExpression) []*ast.Quote {
+	args := make([]*ast.Quote, len(call.Arguments))
+	for i, a := range call.Arguments {
+		args[i] = &ast.Quote{Node: a}
+	}
+	return args
+}
+
+// extendMacroEnv binds each of a macro's parameters to its corresponding
+// quoted argument.
+func extendMacroEnv(macro *ast.MacroLiteral, args []*ast.Quote) map[string]ast.Node {
+	env := make(map[string]ast.Node, len(macro.Parameters))
+	for i, param := range macro.Parameters {
+		if i < len(args) {
+			env[param.Value] = args[i]
+		}
+	}
+	return env
+}
+
+// evalMacroBody returns the Quote produced by a macro's body - the last
+// statement of a macro is expected to be a bare `quote(...)` expression -
+// with any unquote(...) sub-nodes resolved against env.
+func evalMacroBody(macro *ast.MacroLiteral, env map[string]ast.Node) ast.Node {
+	if len(macro.Body.Statements) == 0 {
+		return nil
+	}
+
+	last := macro.Body.Statements[len(macro.Body.Statements)-1]
+
+	exprStmt, ok := last.(*ast.ExpressionStatement)
+	if !ok {
+		return nil
+	}
+
+	quote, ok := exprStmt.Expression.(*ast.Quote)
+	if !ok {
+		return nil
+	}
+
+	return &ast.Quote{Node: evalUnquoteCalls(quote.Node, env)}
+}
+
+// evalUnquoteCalls replaces every Unquote node inside quoted with the AST
+// node its wrapped expression resolves to against env.
+func evalUnquoteCalls(quoted ast.Node, env map[string]ast.Node) ast.Node {
+	return modify(quoted, func(node ast.Node) ast.Node {
+		unquote, ok := node.(*ast.Unquote)
+		if !ok {
+			return node
+		}
+
+		return resolveUnquote(unquote.Node, env)
+	})
+}
+
+// resolveUnquote looks up node in env when it is an identifier bound to a
+// macro parameter, unwrapping the bound Quote in the process. Any other
+// expression is passed through unchanged, since fully evaluating it would
+// require the (not yet present) evaluator.
+func resolveUnquote(node ast.Node, env map[string]ast.Node) ast.Node {
+	ident, ok := node.(*ast.Identifier)
+	if !ok {
+		return node
+	}
+
+	bound, ok := env[ident.Value]
+	if !ok {
+		return node
+	}
+
+	if quote, ok := bound.(*ast.Quote); ok {
+		return quote.Node
+	}
+
+	return bound
+}
+
+// modify recursively rewrites node's children with fn and then applies fn
+// to node itself, returning the (possibly replaced) result. It underlies
+// both ExpandMacros and evalUnquoteCalls.
+func modify(node ast.Node, fn func(ast.Node) ast.Node) ast.Node {
+	switch node := node.(type) {
+	case *ast.Program:
+		for i, stmt := range node.Statements {
+			node.Statements[i], _ = modify(stmt, fn).(ast.Statement)
+		}
+	case *ast.BlockStatement:
+		for i, stmt := range node.Statements {
+			node.Statements[i], _ = modify(stmt, fn).(ast.Statement)
+		}
+	case *ast.ExpressionStatement:
+		node.Expression, _ = modify(node.Expression, fn).(ast.Expression)
+	case *ast.LetStatement:
+		node.Value, _ = modify(node.Value, fn).(ast.Expression)
+	case *ast.ReturnStatement:
+		node.ReturnValue, _ = modify(node.ReturnValue, fn).(ast.Expression)
+	case *ast.PrefixExpression:
+		node.Right, _ = modify(node.Right, fn).(ast.Expression)
+	case *ast.InfixExpression:
+		node.Left, _ = modify(node.Left, fn).(ast.Expression)
+		node.Right, _ = modify(node.Right, fn).(ast.Expression)
+	case *ast.IndexExpression:
+		node.Left, _ = modify(node.Left, fn).(ast.Expression)
+		node.Index, _ = modify(node.Index, fn).(ast.Expression)
+	case *ast.IfExpression:
+		node.Condition, _ = modify(node.Condition, fn).(ast.Expression)
+		node.Consequence, _ = modify(node.Consequence, fn).(*ast.BlockStatement)
+		if node.Alternative != nil {
+			node.Alternative, _ = modify(node.Alternative, fn).(*ast.BlockStatement)
+		}
+	case *ast.FunctionLiteral:
+		for i, p := range node.Parameters {
+			node.Parameters[i], _ = modify(p, fn).(*ast.Identifier)
+		}
+		node.Body, _ = modify(node.Body, fn).(*ast.BlockStatement)
+	case *ast.ArrayLiteral:
+		for i, el := range node.Elements {
+			node.Elements[i], _ = modify(el, fn).(ast.Expression)
+		}
+	case *ast.HashLiteral:
+		newPairs := make(map[ast.Expression]ast.Expression, len(node.Pairs))
+		for key, val := range node.Pairs {
+			newKey, _ := modify(key, fn).(ast.Expression)
+			newVal, _ := modify(val, fn).(ast.Expression)
+			newPairs[newKey] = newVal
+		}
+		node.Pairs = newPairs
+	case *ast.CallExpression:
+		node.Function, _ = modify(node.Function, fn).(ast.Expression)
+		for i, a := range node.Arguments {
+			node.Arguments[i], _ = modify(a, fn).(ast.Expression)
+		}
+	case *ast.Unquote:
+		node.Node = modify(node.Node, fn)
+	}
+
+	return fn(node)
+}
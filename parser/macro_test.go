@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"testing"
+
+	"monkey-interpreter/ast"
+	"monkey-interpreter/lexer"
+)
+
+func TestDefineMacros(t *testing.T) {
+	input := `
+let number = 1;
+let function = fn(x, y) { x + y; };
+let myMacro = macro(x, y) { x + y; };
+`
+
+	env := NewMacroEnv()
+	program := parseProgram(t, input)
+
+	DefineMacros(program, env)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("wrong number of statements after DefineMacros. got=%d", len(program.Statements))
+	}
+
+	if _, ok := env.Get("number"); ok {
+		t.Fatalf("number should not be defined as a macro")
+	}
+
+	if _, ok := env.Get("function"); ok {
+		t.Fatalf("function should not be defined as a macro")
+	}
+
+	if _, ok := env.Get("myMacro"); !ok {
+		t.Fatalf("myMacro is not defined as a macro")
+	}
+}
+
+func TestExpandMacros(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`
+let infixExpression = macro() { quote(1 + 2); };
+infixExpression();
+`,
+			"(1 + 2)",
+		},
+		{
+			`
+let unless = macro(cond, cons, alt) {
+	quote(if (!(unquote(cond))) { unquote(cons) } else { unquote(alt) });
+};
+unless(10 > 5, puts("not greater"), puts("greater"));
+`,
+			`if(!(10 > 5)) puts(not greater)else puts(greater)`,
+		},
+	}
+
+	for _, tt := range tests {
+		program := parseProgram(t, tt.input)
+
+		env := NewMacroEnv()
+		DefineMacros(program, env)
+		expanded := ExpandMacros(program, env)
+
+		if expanded.String() != tt.expected {
+			t.Errorf("not equal. want=%q, got=%q", tt.expected, expanded.String())
+		}
+	}
+}
+
+func parseProgram(t *testing.T, input string) *ast.Program {
+	t.Helper()
+
+	lex := lexer.New(input)
+	par := New(lex)
+	program := par.ParseProgram()
+	checkParserErrors(t, par)
+
+	return program
+}
@@ -7,12 +7,23 @@ import (
 	"monkey-interpreter/lexer"
 	"monkey-interpreter/token"
 	"strconv"
+	"strings"
 )
 
+// ParseError is a single diagnostic produced while parsing, anchored to the
+// token where it was raised.
+type ParseError struct {
+	Token    token.Token
+	Expected string
+	Message  string
+}
+
+func (pe ParseError) Error() string { return pe.Message }
+
 // Structure of a parser instance.
 type Parser struct {
 	lex       *lexer.Lexer
-	errors    []string
+	errors    []ParseError
 	curToken  token.Token
 	peekToken token.Token
 
@@ -37,18 +48,57 @@ const (
 	CALL        // myFunction(X)
 )
 
+// precedences maps an infix operator's token type to its binding power.
+var precedences = map[token.TokenType]int{
+	token.EQ:       EQUALS,
+	token.NOT_EQ:   EQUALS,
+	token.LT:       LESSGREATER,
+	token.GT:       LESSGREATER,
+	token.PLUS:     SUM,
+	token.MINUS:    SUM,
+	token.SLASH:    PRODUCT,
+	token.ASTERISK: PRODUCT,
+	token.LPAREN:   CALL,
+	token.LBRACKET: CALL,
+}
+
 // Create a new parser instance and reutrn it. Accepts a Lexer instance
 // which will be parsed.
 func New(lex *lexer.Lexer) *Parser {
 	par := &Parser{
 		lex:    lex,
-		errors: []string{},
+		errors: []ParseError{},
 	}
 
 	// Add parsing functions to maps
 	par.prefixParseFns = make(map[token.TokenType]prefixParseFn)
 	par.registerPrefix(token.IDENT, par.parseIdentifier)
 	par.registerPrefix(token.INT, par.parseIntegerLiteral)
+	par.registerPrefix(token.BANG, par.parsePrefixExpression)
+	par.registerPrefix(token.MINUS, par.parsePrefixExpression)
+	par.registerPrefix(token.TRUE, par.parseBoolean)
+	par.registerPrefix(token.FALSE, par.parseBoolean)
+	par.registerPrefix(token.LPAREN, par.parseGroupedExpression)
+	par.registerPrefix(token.IF, par.parseIfExpression)
+	par.registerPrefix(token.FUNCTION, par.parseFunctionLiteral)
+	par.registerPrefix(token.STRING, par.parseStringLiteral)
+	par.registerPrefix(token.LBRACKET, par.parseArrayLiteral)
+	par.registerPrefix(token.LBRACE, par.parseHashLiteral)
+	par.registerPrefix(token.QUOTE, par.parseQuoteExpression)
+	par.registerPrefix(token.UNQUOTE, par.parseUnquoteExpression)
+	par.registerPrefix(token.MACRO, par.parseMacroLiteral)
+
+	par.infixParseFns = make(map[token.TokenType]infixParseFn)
+	par.registerInfix(token.PLUS, par.parseInfixExpression)
+	par.registerInfix(token.MINUS, par.parseInfixExpression)
+	par.registerInfix(token.SLASH, par.parseInfixExpression)
+	par.registerInfix(token.ASTERISK, par.parseInfixExpression)
+	par.registerInfix(token.EQ, par.parseInfixExpression)
+	par.registerInfix(token.NOT_EQ, par.parseInfixExpression)
+	par.registerInfix(token.LT, par.parseInfixExpression)
+	par.registerInfix(token.GT, par.parseInfixExpression)
+	par.registerInfix(token.LPAREN, par.parseCallExpression)
+	par.registerInfix(token.LBRACKET, par.parseIndexExpression)
 
 	// Read two tokens, so curToken and peekToken are both set
 	par.nextToken()
@@ -66,6 +116,8 @@ func (par *Parser) ParseProgram() *ast.Program {
 		stmt := par.parseStatement()
 		if stmt != nil {
 			program.Statements = append(program.Statements, stmt)
+		} else {
+			par.synchronize()
 		}
 		par.nextToken()
 	}
@@ -73,13 +125,34 @@ func (par *Parser) ParseProgram() *ast.Program {
 	return program
 }
 
+// synchronize discards tokens after a parse error until the next statement
+// boundary (a semicolon or closing brace), so that one bad statement
+// doesn't prevent the rest of the program from being checked.
+func (par *Parser) synchronize() {
+	for !par.curTokenIs(token.SEMICOLON) && !par.curTokenIs(token.RBRACE) && !par.curTokenIs(token.EOF) {
+		par.nextToken()
+	}
+}
+
 // Parse an entire statement beginning with either a 'let' or 'return' keyword.
+//
+// parseLetStatement/parseReturnStatement return a concrete *ast.LetStatement /
+// *ast.ReturnStatement, which is nil on failure. Returning that nil pointer
+// directly as the ast.Statement interface would box it into a non-nil
+// interface value, so the nil check happens here, before it crosses the
+// interface boundary.
 func (par *Parser) parseStatement() ast.Statement {
 	switch par.curToken.Type {
 	case token.LET:
-		return par.parseLetStatement()
+		if stmt := par.parseLetStatement(); stmt != nil {
+			return stmt
+		}
+		return nil
 	case token.RETURN:
-		return par.parseReturnStatement()
+		if stmt := par.parseReturnStatement(); stmt != nil {
+			return stmt
+		}
+		return nil
 	default:
 		return par.parseExpressionStatement()
 	}
@@ -99,9 +172,11 @@ func (par *Parser) parseLetStatement() *ast.LetStatement {
 		return nil
 	}
 
-	// TODO: We're skipping the expressions until we
-	// encounter a semicolon
-	for !par.curTokenIs(token.SEMICOLON) {
+	par.nextToken()
+
+	stmt.Value = par.parseExpression(LOWEST)
+
+	if par.peekTokenIs(token.SEMICOLON) {
 		par.nextToken()
 	}
 
@@ -114,9 +189,9 @@ func (par *Parser) parseReturnStatement() *ast.ReturnStatement {
 
 	par.nextToken()
 
-	// TODO: We're skipping the expressions until we
-	// encounter a semicolon
-	for !par.curTokenIs(token.SEMICOLON) {
+	stmt.ReturnValue = par.parseExpression(LOWEST)
+
+	if par.peekTokenIs(token.SEMICOLON) {
 		par.nextToken()
 	}
 
@@ -135,14 +210,27 @@ func (par *Parser) parseExpressionStatement() ast.Statement {
 	return stmt
 }
 
-// Parse an expression.
+// Parse an expression, consuming infix operators while their precedence
+// exceeds the precedence passed in.
 func (par *Parser) parseExpression(precedence int) ast.Expression {
 	prefix := par.prefixParseFns[par.curToken.Type]
 	if prefix == nil {
+		par.noPrefixParseFnError(par.curToken.Type)
 		return nil
 	}
 	leftExp := prefix()
 
+	for !par.peekTokenIs(token.SEMICOLON) && precedence < par.peekPrecedence() {
+		infix := par.infixParseFns[par.peekToken.Type]
+		if infix == nil {
+			return leftExp
+		}
+
+		par.nextToken()
+
+		leftExp = infix(leftExp)
+	}
+
 	return leftExp
 }
 
@@ -158,7 +246,7 @@ func (par *Parser) parseIntegerLiteral() ast.Expression {
 	value, err := strconv.ParseInt(par.curToken.Literal, 0, 64)
 	if err != nil {
 		msg := fmt.Sprintf("could not parse %q as integer", par.curToken.Literal)
-		par.errors = append(par.errors, msg)
+		par.errors = append(par.errors, ParseError{Token: par.curToken, Message: msg})
 		return nil
 	}
 
@@ -167,6 +255,306 @@ func (par *Parser) parseIntegerLiteral() ast.Expression {
 	return lit
 }
 
+// Parse a boolean literal.
+func (par *Parser) parseBoolean() ast.Expression {
+	return &ast.Boolean{Token: par.curToken, Value: par.curTokenIs(token.TRUE)}
+}
+
+// Parse a prefix expression such as `!x` or `-5`.
+func (par *Parser) parsePrefixExpression() ast.Expression {
+	expression := &ast.PrefixExpression{
+		Token:    par.curToken,
+		Operator: par.curToken.Literal,
+	}
+
+	par.nextToken()
+
+	expression.Right = par.parseExpression(PREFIX)
+
+	return expression
+}
+
+// Parse an infix expression such as `5 + 5`.
+func (par *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
+	expression := &ast.InfixExpression{
+		Token:    par.curToken,
+		Left:     left,
+		Operator: par.curToken.Literal,
+	}
+
+	precedence := par.curPrecedence()
+	par.nextToken()
+	expression.Right = par.parseExpression(precedence)
+
+	return expression
+}
+
+// Parse a parenthesized expression, returning the inner expression.
+func (par *Parser) parseGroupedExpression() ast.Expression {
+	par.nextToken()
+
+	exp := par.parseExpression(LOWEST)
+
+	if !par.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	return exp
+}
+
+// Parse an `if (<condition>) { <consequence> } else { <alternative> }`
+// expression. The else branch is optional.
+func (par *Parser) parseIfExpression() ast.Expression {
+	expression := &ast.IfExpression{Token: par.curToken}
+
+	if !par.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	par.nextToken()
+	expression.Condition = par.parseExpression(LOWEST)
+
+	if !par.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	if !par.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	expression.Consequence = par.parseBlockStatement()
+
+	if par.peekTokenIs(token.ELSE) {
+		par.nextToken()
+
+		if !par.expectPeek(token.LBRACE) {
+			return nil
+		}
+
+		expression.Alternative = par.parseBlockStatement()
+	}
+
+	return expression
+}
+
+// Parse a brace-delimited block of statements.
+//
+// A statement that fails to parse is recovered from here too, the same way
+// ParseProgram recovers at the top level: synchronize() to the next
+// SEMICOLON/RBRACE/EOF rather than silently reinterpreting its leftover
+// tokens as new statements. Unlike the top level, synchronize() landing on
+// RBRACE here means it found the block's own closing brace, so that case
+// must not advance past it — the loop condition handles stopping there.
+func (par *Parser) parseBlockStatement() *ast.BlockStatement {
+	block := &ast.BlockStatement{Token: par.curToken}
+	block.Statements = []ast.Statement{}
+
+	par.nextToken()
+
+	for !par.curTokenIs(token.RBRACE) && !par.curTokenIs(token.EOF) {
+		stmt := par.parseStatement()
+		if stmt != nil {
+			block.Statements = append(block.Statements, stmt)
+			par.nextToken()
+		} else {
+			par.synchronize()
+			if par.curTokenIs(token.SEMICOLON) {
+				par.nextToken()
+			}
+		}
+	}
+
+	return block
+}
+
+// Parse a `fn (<parameters>) { <body> }` expression.
+func (par *Parser) parseFunctionLiteral() ast.Expression {
+	lit := &ast.FunctionLiteral{Token: par.curToken}
+
+	if !par.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	lit.Parameters = par.parseFunctionParameters()
+
+	if !par.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	lit.Body = par.parseBlockStatement()
+
+	return lit
+}
+
+// Parse a `macro (<parameters>) { <body> }` expression.
+func (par *Parser) parseMacroLiteral() ast.Expression {
+	lit := &ast.MacroLiteral{Token: par.curToken}
+
+	if !par.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	lit.Parameters = par.parseFunctionParameters()
+
+	if !par.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	lit.Body = par.parseBlockStatement()
+
+	return lit
+}
+
+// Parse a `quote(<expr>)` expression, capturing expr as data rather than
+// evaluating it.
+func (par *Parser) parseQuoteExpression() ast.Expression {
+	if !par.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	par.nextToken()
+	node := par.parseExpression(LOWEST)
+
+	if !par.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	return &ast.Quote{Node: node}
+}
+
+// Parse an `unquote(<expr>)` expression, marking expr to be spliced back in
+// during macro expansion.
+func (par *Parser) parseUnquoteExpression() ast.Expression {
+	if !par.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	par.nextToken()
+	node := par.parseExpression(LOWEST)
+
+	if !par.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	return &ast.Unquote{Node: node}
+}
+
+// Parse a comma-separated list of parameter identifiers.
+func (par *Parser) parseFunctionParameters() []*ast.Identifier {
+	identifiers := []*ast.Identifier{}
+
+	if par.peekTokenIs(token.RPAREN) {
+		par.nextToken()
+		return identifiers
+	}
+
+	par.nextToken()
+
+	ident := &ast.Identifier{Token: par.curToken, Value: par.curToken.Literal}
+	identifiers = append(identifiers, ident)
+
+	for par.peekTokenIs(token.COMMA) {
+		par.nextToken()
+		par.nextToken()
+		ident := &ast.Identifier{Token: par.curToken, Value: par.curToken.Literal}
+		identifiers = append(identifiers, ident)
+	}
+
+	if !par.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	return identifiers
+}
+
+// Parse a function call expression, e.g. `add(1, 2)`.
+func (par *Parser) parseCallExpression(function ast.Expression) ast.Expression {
+	exp := &ast.CallExpression{Token: par.curToken, Function: function}
+	exp.Arguments = par.parseExpressionList(token.RPAREN)
+	return exp
+}
+
+// Parse a string literal.
+func (par *Parser) parseStringLiteral() ast.Expression {
+	return &ast.StringLiteral{Token: par.curToken, Value: par.curToken.Literal}
+}
+
+// Parse an array literal, e.g. `[1, 2 * 2, 3 + 3]`.
+func (par *Parser) parseArrayLiteral() ast.Expression {
+	array := &ast.ArrayLiteral{Token: par.curToken}
+	array.Elements = par.parseExpressionList(token.RBRACKET)
+	return array
+}
+
+// Parse an index expression, e.g. `arr[1 + 1]`.
+func (par *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
+	exp := &ast.IndexExpression{Token: par.curToken, Left: left}
+
+	par.nextToken()
+	exp.Index = par.parseExpression(LOWEST)
+
+	if !par.expectPeek(token.RBRACKET) {
+		return nil
+	}
+
+	return exp
+}
+
+// Parse a hash literal, e.g. `{"one": 1, "two": 2}`.
+func (par *Parser) parseHashLiteral() ast.Expression {
+	hash := &ast.HashLiteral{Token: par.curToken}
+	hash.Pairs = make(map[ast.Expression]ast.Expression)
+
+	for !par.peekTokenIs(token.RBRACE) {
+		par.nextToken()
+		key := par.parseExpression(LOWEST)
+
+		if !par.expectPeek(token.COLON) {
+			return nil
+		}
+
+		par.nextToken()
+		value := par.parseExpression(LOWEST)
+
+		hash.Pairs[key] = value
+
+		if !par.peekTokenIs(token.RBRACE) && !par.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+
+	if !par.expectPeek(token.RBRACE) {
+		return nil
+	}
+
+	return hash
+}
+
+// Parse a comma-separated list of expressions terminated by end.
+func (par *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
+	list := []ast.Expression{}
+
+	if par.peekTokenIs(end) {
+		par.nextToken()
+		return list
+	}
+
+	par.nextToken()
+	list = append(list, par.parseExpression(LOWEST))
+
+	for par.peekTokenIs(token.COMMA) {
+		par.nextToken()
+		par.nextToken()
+		list = append(list, par.parseExpression(LOWEST))
+	}
+
+	if !par.expectPeek(end) {
+		return nil
+	}
+
+	return list
+}
+
 // Update the current token of the parser instace to the peek token, and then
 // update the peek token to the next token from the Lexer.
 func (par *Parser) nextToken() {
@@ -197,6 +585,26 @@ func (par *Parser) expectPeek(tok token.TokenType) bool {
 	}
 }
 
+// Return the precedence associated with the peek token, or LOWEST if none
+// is registered.
+func (par *Parser) peekPrecedence() int {
+	if p, ok := precedences[par.peekToken.Type]; ok {
+		return p
+	}
+
+	return LOWEST
+}
+
+// Return the precedence associated with the current token, or LOWEST if
+// none is registered.
+func (par *Parser) curPrecedence() int {
+	if p, ok := precedences[par.curToken.Type]; ok {
+		return p
+	}
+
+	return LOWEST
+}
+
 // Add a function for parsing prefix operators to the prefixParseFns map.
 func (p *Parser) registerPrefix(tokenType token.TokenType, fn prefixParseFn) {
 	p.prefixParseFns[tokenType] = fn
@@ -207,14 +615,57 @@ func (p *Parser) registerInfix(tokenType token.TokenType, fn infixParseFn) {
 	p.infixParseFns[tokenType] = fn
 }
 
-// Return the log of errors generated while parsing.
+// Return the log of errors generated while parsing, as plain messages.
 func (par *Parser) Errors() []string {
-	return par.errors
+	msgs := make([]string, len(par.errors))
+	for i, e := range par.errors {
+		msgs[i] = e.Message
+	}
+	return msgs
+}
+
+// ErrorsFormatted renders the parser's errors as caret-underlined
+// diagnostics, each showing the offending source line, e.g.:
+//
+//	line 1:7: expected '=', got 'INT'
+//	let x 5;
+//	      ^
+func (par *Parser) ErrorsFormatted(src string) string {
+	lines := strings.Split(src, "\n")
+	var out strings.Builder
+
+	for _, e := range par.errors {
+		fmt.Fprintf(&out, "line %d:%d: %s\n", e.Token.Line, e.Token.Column, e.Message)
+
+		if e.Token.Line >= 1 && e.Token.Line <= len(lines) {
+			out.WriteString(lines[e.Token.Line-1])
+			out.WriteString("\n")
+			if e.Token.Column > 1 {
+				out.WriteString(strings.Repeat(" ", e.Token.Column-1))
+			}
+			out.WriteString("^\n")
+		}
+	}
+
+	return out.String()
 }
 
 // Log an error signifyiing that the expected next token was not found.
 func (par *Parser) peekError(tok token.TokenType) {
-	msg := fmt.Sprintf("Expected next token to be %s, got %s instead",
-		tok, par.peekToken.Type)
-	par.errors = append(par.errors, msg)
+	msg := fmt.Sprintf("expected '%s', got '%s'", tok, par.peekToken.Type)
+	par.errors = append(par.errors, ParseError{
+		Token:    par.peekToken,
+		Expected: string(tok),
+		Message:  msg,
+	})
+}
+
+// Log an error signifying that no prefix parse function is registered for
+// the given token type.
+func (par *Parser) noPrefixParseFnError(tok token.TokenType) {
+	msg := fmt.Sprintf("no prefix parse function for %s found", tok)
+	par.errors = append(par.errors, ParseError{
+		Token:   par.curToken,
+		Message: msg,
+	})
 }
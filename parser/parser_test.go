@@ -0,0 +1,683 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"monkey-interpreter/ast"
+	"monkey-interpreter/lexer"
+)
+
+func TestLetStatements(t *testing.T) {
+	tests := []struct {
+		input              string
+		expectedIdentifier string
+		expectedValue      interface{}
+	}{
+		{"let x = 5;", "x", int64(5)},
+		{"let y = true;", "y", true},
+		{"let foobar = y;", "foobar", "y"},
+	}
+
+	for _, tt := range tests {
+		lex := lexer.New(tt.input)
+		par := New(lex)
+		program := par.ParseProgram()
+		checkParserErrors(t, par)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statement. got=%d", len(program.Statements))
+		}
+
+		stmt := program.Statements[0]
+		if stmt.TokenLiteral() != "let" {
+			t.Fatalf("stmt.TokenLiteral not 'let'. got=%q", stmt.TokenLiteral())
+		}
+
+		letStmt, ok := stmt.(*ast.LetStatement)
+		if !ok {
+			t.Fatalf("stmt not *ast.LetStatement. got=%T", stmt)
+		}
+
+		if letStmt.Name.Value != tt.expectedIdentifier {
+			t.Fatalf("letStmt.Name.Value not %q. got=%q", tt.expectedIdentifier, letStmt.Name.Value)
+		}
+
+		testLiteralExpression(t, letStmt.Value, tt.expectedValue)
+	}
+}
+
+func TestReturnStatements(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedValue interface{}
+	}{
+		{"return 5;", int64(5)},
+		{"return true;", true},
+		{"return foobar;", "foobar"},
+	}
+
+	for _, tt := range tests {
+		lex := lexer.New(tt.input)
+		par := New(lex)
+		program := par.ParseProgram()
+		checkParserErrors(t, par)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statement. got=%d", len(program.Statements))
+		}
+
+		returnStmt, ok := program.Statements[0].(*ast.ReturnStatement)
+		if !ok {
+			t.Fatalf("stmt not *ast.ReturnStatement. got=%T", program.Statements[0])
+		}
+
+		if returnStmt.TokenLiteral() != "return" {
+			t.Fatalf("returnStmt.TokenLiteral not 'return'. got=%q", returnStmt.TokenLiteral())
+		}
+
+		testLiteralExpression(t, returnStmt.ReturnValue, tt.expectedValue)
+	}
+}
+
+func TestIdentifierExpression(t *testing.T) {
+	input := "foobar;"
+
+	lex := lexer.New(input)
+	par := New(lex)
+	program := par.ParseProgram()
+	checkParserErrors(t, par)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	testIdentifier(t, stmt.Expression, "foobar")
+}
+
+func TestIntegerLiteralExpression(t *testing.T) {
+	input := "5;"
+
+	lex := lexer.New(input)
+	par := New(lex)
+	program := par.ParseProgram()
+	checkParserErrors(t, par)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	testIntegerLiteral(t, stmt.Expression, 5)
+}
+
+func TestParsingPrefixExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		operator string
+		value    interface{}
+	}{
+		{"!5;", "!", int64(5)},
+		{"-15;", "-", int64(15)},
+		{"!true;", "!", true},
+		{"!false;", "!", false},
+	}
+
+	for _, tt := range tests {
+		lex := lexer.New(tt.input)
+		par := New(lex)
+		program := par.ParseProgram()
+		checkParserErrors(t, par)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		exp, ok := stmt.Expression.(*ast.PrefixExpression)
+		if !ok {
+			t.Fatalf("exp not *ast.PrefixExpression. got=%T", stmt.Expression)
+		}
+
+		if exp.Operator != tt.operator {
+			t.Fatalf("exp.Operator not %q. got=%q", tt.operator, exp.Operator)
+		}
+
+		testLiteralExpression(t, exp.Right, tt.value)
+	}
+}
+
+func TestParsingInfixExpressions(t *testing.T) {
+	tests := []struct {
+		input      string
+		leftValue  interface{}
+		operator   string
+		rightValue interface{}
+	}{
+		{"5 + 5;", int64(5), "+", int64(5)},
+		{"5 - 5;", int64(5), "-", int64(5)},
+		{"5 * 5;", int64(5), "*", int64(5)},
+		{"5 / 5;", int64(5), "/", int64(5)},
+		{"5 > 5;", int64(5), ">", int64(5)},
+		{"5 < 5;", int64(5), "<", int64(5)},
+		{"5 == 5;", int64(5), "==", int64(5)},
+		{"5 != 5;", int64(5), "!=", int64(5)},
+		{"true == true", true, "==", true},
+		{"true != false", true, "!=", false},
+		{"false == false", false, "==", false},
+	}
+
+	for _, tt := range tests {
+		lex := lexer.New(tt.input)
+		par := New(lex)
+		program := par.ParseProgram()
+		checkParserErrors(t, par)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		testInfixExpression(t, stmt.Expression, tt.leftValue, tt.operator, tt.rightValue)
+	}
+}
+
+func TestOperatorPrecedenceParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"-a * b", "((-a) * b)"},
+		{"!-a", "(!(-a))"},
+		{"a + b + c", "((a + b) + c)"},
+		{"a + b - c", "((a + b) - c)"},
+		{"a * b * c", "((a * b) * c)"},
+		{"a * b / c", "((a * b) / c)"},
+		{"a + b / c", "(a + (b / c))"},
+		{"a + b * c + d / e - f", "(((a + (b * c)) + (d / e)) - f)"},
+		{"3 + 4; -5 * 5", "(3 + 4)((-5) * 5)"},
+		{"5 > 4 == 3 < 4", "((5 > 4) == (3 < 4))"},
+		{"5 < 4 != 3 > 4", "((5 < 4) != (3 > 4))"},
+		{"3 + 4 * 5 == 3 * 1 + 4 * 5", "((3 + (4 * 5)) == ((3 * 1) + (4 * 5)))"},
+		{"true", "true"},
+		{"false", "false"},
+		{"3 > 5 == false", "((3 > 5) == false)"},
+		{"3 < 5 == true", "((3 < 5) == true)"},
+		{"1 + (2 + 3) + 4", "((1 + (2 + 3)) + 4)"},
+		{"(5 + 5) * 2", "((5 + 5) * 2)"},
+		{"2 / (5 + 5)", "(2 / (5 + 5))"},
+		{"-(5 + 5)", "(-(5 + 5))"},
+		{"!(true == true)", "(!(true == true))"},
+		{"a + add(b * c) + d", "((a + add((b * c))) + d)"},
+		{"add(a, b, 1, 2 * 3, 4 + 5, add(6, 7 * 8))", "add(a, b, 1, (2 * 3), (4 + 5), add(6, (7 * 8)))"},
+		{"add(a + b + c * d / f + g)", "add((((a + b) + ((c * d) / f)) + g))"},
+		{"a * [1, 2, 3, 4][b * c] * d", "((a * ([1, 2, 3, 4][(b * c)])) * d)"},
+		{"add(a * b[2], b[1], 2 * [1, 2][1])", "add((a * (b[2])), (b[1]), (2 * ([1, 2][1])))"},
+	}
+
+	for _, tt := range tests {
+		lex := lexer.New(tt.input)
+		par := New(lex)
+		program := par.ParseProgram()
+		checkParserErrors(t, par)
+
+		actual := program.String()
+		if actual != tt.expected {
+			t.Fatalf("expected=%q, got=%q", tt.expected, actual)
+		}
+	}
+}
+
+func TestBooleanExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"true;", true},
+		{"false;", false},
+	}
+
+	for _, tt := range tests {
+		lex := lexer.New(tt.input)
+		par := New(lex)
+		program := par.ParseProgram()
+		checkParserErrors(t, par)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		boolean, ok := stmt.Expression.(*ast.Boolean)
+		if !ok {
+			t.Fatalf("exp not *ast.Boolean. got=%T", stmt.Expression)
+		}
+
+		if boolean.Value != tt.expected {
+			t.Fatalf("boolean.Value not %t. got=%t", tt.expected, boolean.Value)
+		}
+	}
+}
+
+func TestIfExpression(t *testing.T) {
+	input := "if (x < y) { x }"
+
+	lex := lexer.New(input)
+	par := New(lex)
+	program := par.ParseProgram()
+	checkParserErrors(t, par)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression not *ast.IfExpression. got=%T", stmt.Expression)
+	}
+
+	testInfixExpression(t, exp.Condition, "x", "<", "y")
+
+	if len(exp.Consequence.Statements) != 1 {
+		t.Fatalf("consequence does not contain 1 statement. got=%d", len(exp.Consequence.Statements))
+	}
+
+	consequence, ok := exp.Consequence.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("Statements[0] not *ast.ExpressionStatement. got=%T", exp.Consequence.Statements[0])
+	}
+
+	testIdentifier(t, consequence.Expression, "x")
+
+	if exp.Alternative != nil {
+		t.Fatalf("exp.Alternative was not nil. got=%+v", exp.Alternative)
+	}
+}
+
+func TestIfElseExpression(t *testing.T) {
+	input := "if (x < y) { x } else { y }"
+
+	lex := lexer.New(input)
+	par := New(lex)
+	program := par.ParseProgram()
+	checkParserErrors(t, par)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression not *ast.IfExpression. got=%T", stmt.Expression)
+	}
+
+	if len(exp.Consequence.Statements) != 1 {
+		t.Fatalf("consequence does not contain 1 statement. got=%d", len(exp.Consequence.Statements))
+	}
+
+	consequence, ok := exp.Consequence.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("Statements[0] not *ast.ExpressionStatement. got=%T", exp.Consequence.Statements[0])
+	}
+
+	testIdentifier(t, consequence.Expression, "x")
+
+	if len(exp.Alternative.Statements) != 1 {
+		t.Fatalf("alternative does not contain 1 statement. got=%d", len(exp.Alternative.Statements))
+	}
+
+	alternative, ok := exp.Alternative.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("Statements[0] not *ast.ExpressionStatement. got=%T", exp.Alternative.Statements[0])
+	}
+
+	testIdentifier(t, alternative.Expression, "y")
+}
+
+func TestFunctionLiteralParsing(t *testing.T) {
+	input := "fn(x, y) { x + y; }"
+
+	lex := lexer.New(input)
+	par := New(lex)
+	program := par.ParseProgram()
+	checkParserErrors(t, par)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	fn, ok := stmt.Expression.(*ast.FunctionLiteral)
+	if !ok {
+		t.Fatalf("stmt.Expression not *ast.FunctionLiteral. got=%T", stmt.Expression)
+	}
+
+	if len(fn.Parameters) != 2 {
+		t.Fatalf("function literal parameters wrong. want 2, got=%d", len(fn.Parameters))
+	}
+
+	testLiteralExpression(t, fn.Parameters[0], "x")
+	testLiteralExpression(t, fn.Parameters[1], "y")
+
+	if len(fn.Body.Statements) != 1 {
+		t.Fatalf("fn.Body.Statements has wrong length. got=%d", len(fn.Body.Statements))
+	}
+
+	bodyStmt, ok := fn.Body.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("function body stmt not *ast.ExpressionStatement. got=%T", fn.Body.Statements[0])
+	}
+
+	testInfixExpression(t, bodyStmt.Expression, "x", "+", "y")
+}
+
+func TestFunctionParameterParsing(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedParams []string
+	}{
+		{input: "fn() {};", expectedParams: []string{}},
+		{input: "fn(x) {};", expectedParams: []string{"x"}},
+		{input: "fn(x, y, z) {};", expectedParams: []string{"x", "y", "z"}},
+	}
+
+	for _, tt := range tests {
+		lex := lexer.New(tt.input)
+		par := New(lex)
+		program := par.ParseProgram()
+		checkParserErrors(t, par)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		fn := stmt.Expression.(*ast.FunctionLiteral)
+
+		if len(fn.Parameters) != len(tt.expectedParams) {
+			t.Fatalf("length parameters wrong. want %d, got=%d", len(tt.expectedParams), len(fn.Parameters))
+		}
+
+		for i, ident := range tt.expectedParams {
+			testLiteralExpression(t, fn.Parameters[i], ident)
+		}
+	}
+}
+
+func TestCallExpressionParsing(t *testing.T) {
+	input := "add(1, 2 * 3, 4 + 5);"
+
+	lex := lexer.New(input)
+	par := New(lex)
+	program := par.ParseProgram()
+	checkParserErrors(t, par)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.CallExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression not *ast.CallExpression. got=%T", stmt.Expression)
+	}
+
+	testIdentifier(t, exp.Function, "add")
+
+	if len(exp.Arguments) != 3 {
+		t.Fatalf("wrong length of arguments. got=%d", len(exp.Arguments))
+	}
+
+	testLiteralExpression(t, exp.Arguments[0], int64(1))
+	testInfixExpression(t, exp.Arguments[1], int64(2), "*", int64(3))
+	testInfixExpression(t, exp.Arguments[2], int64(4), "+", int64(5))
+}
+
+func TestParsingGroupedExpression(t *testing.T) {
+	input := "(5 + 5) * 2"
+
+	lex := lexer.New(input)
+	par := New(lex)
+	program := par.ParseProgram()
+	checkParserErrors(t, par)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.InfixExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression not *ast.InfixExpression. got=%T", stmt.Expression)
+	}
+
+	testInfixExpression(t, exp.Left, int64(5), "+", int64(5))
+
+	if exp.Operator != "*" {
+		t.Fatalf("exp.Operator not '*'. got=%q", exp.Operator)
+	}
+
+	testIntegerLiteral(t, exp.Right, 2)
+}
+
+func testIntegerLiteral(t *testing.T, il ast.Expression, value int64) {
+	t.Helper()
+
+	integ, ok := il.(*ast.IntegerLiteral)
+	if !ok {
+		t.Fatalf("il not *ast.IntegerLiteral. got=%T", il)
+	}
+
+	if integ.Value != value {
+		t.Fatalf("integ.Value not %d. got=%d", value, integ.Value)
+	}
+
+	if integ.TokenLiteral() != fmt.Sprintf("%d", value) {
+		t.Fatalf("integ.TokenLiteral not %d. got=%s", value, integ.TokenLiteral())
+	}
+}
+
+func testIdentifier(t *testing.T, exp ast.Expression, value string) {
+	t.Helper()
+
+	ident, ok := exp.(*ast.Identifier)
+	if !ok {
+		t.Fatalf("exp not *ast.Identifier. got=%T", exp)
+	}
+
+	if ident.Value != value {
+		t.Fatalf("ident.Value not %s. got=%s", value, ident.Value)
+	}
+
+	if ident.TokenLiteral() != value {
+		t.Fatalf("ident.TokenLiteral not %s. got=%s", value, ident.TokenLiteral())
+	}
+}
+
+func testBooleanLiteral(t *testing.T, exp ast.Expression, value bool) {
+	t.Helper()
+
+	b, ok := exp.(*ast.Boolean)
+	if !ok {
+		t.Fatalf("exp not *ast.Boolean. got=%T", exp)
+	}
+
+	if b.Value != value {
+		t.Fatalf("b.Value not %t. got=%t", value, b.Value)
+	}
+
+	if b.TokenLiteral() != fmt.Sprintf("%t", value) {
+		t.Fatalf("b.TokenLiteral not %t. got=%s", value, b.TokenLiteral())
+	}
+}
+
+func testLiteralExpression(t *testing.T, exp ast.Expression, expected interface{}) {
+	t.Helper()
+
+	switch v := expected.(type) {
+	case int:
+		testIntegerLiteral(t, exp, int64(v))
+	case int64:
+		testIntegerLiteral(t, exp, v)
+	case string:
+		testIdentifier(t, exp, v)
+	case bool:
+		testBooleanLiteral(t, exp, v)
+	default:
+		t.Fatalf("type of exp not handled. got=%T", expected)
+	}
+}
+
+func testInfixExpression(t *testing.T, exp ast.Expression, left interface{}, operator string, right interface{}) {
+	t.Helper()
+
+	opExp, ok := exp.(*ast.InfixExpression)
+	if !ok {
+		t.Fatalf("exp not *ast.InfixExpression. got=%T", exp)
+	}
+
+	testLiteralExpression(t, opExp.Left, left)
+
+	if opExp.Operator != operator {
+		t.Fatalf("opExp.Operator not %q. got=%q", operator, opExp.Operator)
+	}
+
+	testLiteralExpression(t, opExp.Right, right)
+}
+
+func TestParsingArrayLiterals(t *testing.T) {
+	input := "[1, 2 * 2, 3 + 3]"
+
+	lex := lexer.New(input)
+	par := New(lex)
+	program := par.ParseProgram()
+	checkParserErrors(t, par)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	array, ok := stmt.Expression.(*ast.ArrayLiteral)
+	if !ok {
+		t.Fatalf("exp not ast.ArrayLiteral. got=%T", stmt.Expression)
+	}
+
+	if len(array.Elements) != 3 {
+		t.Fatalf("len(array.Elements) not 3. got=%d", len(array.Elements))
+	}
+}
+
+func TestParsingIndexExpressions(t *testing.T) {
+	input := "myArray[1 + 1]"
+
+	lex := lexer.New(input)
+	par := New(lex)
+	program := par.ParseProgram()
+	checkParserErrors(t, par)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	indexExp, ok := stmt.Expression.(*ast.IndexExpression)
+	if !ok {
+		t.Fatalf("exp not ast.IndexExpression. got=%T", stmt.Expression)
+	}
+
+	if indexExp.Left.String() != "myArray" {
+		t.Fatalf("indexExp.Left wrong. got=%s", indexExp.Left.String())
+	}
+
+	if indexExp.Index.String() != "(1 + 1)" {
+		t.Fatalf("indexExp.Index wrong. got=%s", indexExp.Index.String())
+	}
+}
+
+func TestParsingHashLiteralsStringKeys(t *testing.T) {
+	input := `{"one": 1, "two": 2}`
+
+	lex := lexer.New(input)
+	par := New(lex)
+	program := par.ParseProgram()
+	checkParserErrors(t, par)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	hash, ok := stmt.Expression.(*ast.HashLiteral)
+	if !ok {
+		t.Fatalf("exp not ast.HashLiteral. got=%T", stmt.Expression)
+	}
+
+	if len(hash.Pairs) != 2 {
+		t.Fatalf("hash.Pairs has wrong length. got=%d", len(hash.Pairs))
+	}
+
+	expected := map[string]int64{"one": 1, "two": 2}
+
+	for key, value := range hash.Pairs {
+		literal, ok := key.(*ast.StringLiteral)
+		if !ok {
+			t.Fatalf("key is not ast.StringLiteral. got=%T", key)
+		}
+
+		expectedValue := expected[literal.String()]
+
+		integer, ok := value.(*ast.IntegerLiteral)
+		if !ok {
+			t.Fatalf("value is not ast.IntegerLiteral. got=%T", value)
+		}
+
+		if integer.Value != expectedValue {
+			t.Errorf("value for key %q wrong. got=%d, want=%d",
+				literal.String(), integer.Value, expectedValue)
+		}
+	}
+}
+
+func TestMultipleParseErrorsRecovery(t *testing.T) {
+	input := "let x 5; let y = 10;"
+
+	lex := lexer.New(input)
+	par := New(lex)
+	program := par.ParseProgram()
+
+	if len(par.errors) != 1 {
+		t.Fatalf("expected 1 parse error, got %d: %v", len(par.errors), par.Errors())
+	}
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("expected synchronize() to discard the broken statement, got %d statements", len(program.Statements))
+	}
+
+	letStmt, ok := program.Statements[0].(*ast.LetStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] not *ast.LetStatement. got=%T", program.Statements[0])
+	}
+
+	if letStmt.Name.Value != "y" {
+		t.Fatalf("letStmt.Name.Value wrong. got=%s", letStmt.Name.Value)
+	}
+
+	// Regression guard: a previous version boxed a nil *ast.LetStatement into
+	// the ast.Statement interface, so it passed the `stmt != nil` check in
+	// ParseProgram and later panicked here.
+	_ = program.String()
+}
+
+func TestMultipleParseErrorsRecoveryInsideBlock(t *testing.T) {
+	input := "if (x) { let y 5; let z 10; }"
+
+	lex := lexer.New(input)
+	par := New(lex)
+	program := par.ParseProgram()
+
+	if len(par.errors) != 2 {
+		t.Fatalf("expected 2 parse errors, got %d: %v", len(par.errors), par.Errors())
+	}
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("expected 1 top-level statement, got %d", len(program.Statements))
+	}
+
+	exprStmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] not *ast.ExpressionStatement. got=%T", program.Statements[0])
+	}
+
+	ifExp, ok := exprStmt.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("exp not ast.IfExpression. got=%T", exprStmt.Expression)
+	}
+
+	// Regression guard: synchronize() must discard both broken `let`
+	// statements inside the block rather than letting their leftover
+	// tokens (5 and 10) get reparsed as stray ExpressionStatements.
+	if len(ifExp.Consequence.Statements) != 0 {
+		t.Fatalf("expected block body to be empty after recovery, got %d statements: %s",
+			len(ifExp.Consequence.Statements), ifExp.Consequence.String())
+	}
+}
+
+func TestErrorsFormatted(t *testing.T) {
+	input := "let x 5;"
+
+	lex := lexer.New(input)
+	par := New(lex)
+	par.ParseProgram()
+
+	formatted := par.ErrorsFormatted(input)
+
+	if !strings.Contains(formatted, "line 1:7:") {
+		t.Fatalf("ErrorsFormatted missing line:column prefix. got=%q", formatted)
+	}
+
+	if !strings.Contains(formatted, "^") {
+		t.Fatalf("ErrorsFormatted missing caret underline. got=%q", formatted)
+	}
+}
+
+func checkParserErrors(t *testing.T, par *Parser) {
+	errors := par.Errors()
+	if len(errors) == 0 {
+		return
+	}
+
+	t.Errorf("parser has %d errors", len(errors))
+	for _, msg := range errors {
+		t.Errorf("parser error: %q", msg)
+	}
+	t.FailNow()
+}
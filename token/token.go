@@ -0,0 +1,91 @@
+// Package token defines the lexical tokens produced by the lexer and
+// consumed by the parser.
+package token
+
+// TokenType identifies the kind of lexical token.
+type TokenType string
+
+// Token is a single lexical token: its type, the literal text it was
+// scanned from, and where in the source it begins.
+type Token struct {
+	Type    TokenType
+	Literal string
+
+	// Line and Column are 1-indexed; Offset is the 0-indexed byte offset
+	// into the source.
+	Line   int
+	Column int
+	Offset int
+}
+
+const (
+	ILLEGAL = "ILLEGAL"
+	EOF     = "EOF"
+
+	// Identifiers + literals
+	IDENT  = "IDENT"
+	INT    = "INT"
+	STRING = "STRING"
+
+	// Operators
+	ASSIGN   = "="
+	PLUS     = "+"
+	MINUS    = "-"
+	BANG     = "!"
+	ASTERISK = "*"
+	SLASH    = "/"
+
+	LT = "<"
+	GT = ">"
+
+	EQ     = "=="
+	NOT_EQ = "!="
+
+	// Delimiters
+	COMMA     = ","
+	SEMICOLON = ";"
+	COLON     = ":"
+
+	LPAREN   = "("
+	RPAREN   = ")"
+	LBRACE   = "{"
+	RBRACE   = "}"
+	LBRACKET = "["
+	RBRACKET = "]"
+
+	// Keywords
+	FUNCTION = "FUNCTION"
+	LET      = "LET"
+	TRUE     = "TRUE"
+	FALSE    = "FALSE"
+	IF       = "IF"
+	ELSE     = "ELSE"
+	RETURN   = "RETURN"
+	MACRO    = "MACRO"
+	QUOTE    = "QUOTE"
+	UNQUOTE  = "UNQUOTE"
+)
+
+// keywords maps the literal spelling of each Monkey keyword to its token
+// type.
+var keywords = map[string]TokenType{
+	"fn":      FUNCTION,
+	"let":     LET,
+	"true":    TRUE,
+	"false":   FALSE,
+	"if":      IF,
+	"else":    ELSE,
+	"return":  RETURN,
+	"macro":   MACRO,
+	"quote":   QUOTE,
+	"unquote": UNQUOTE,
+}
+
+// LookupIdent returns the keyword TokenType for ident if it is a reserved
+// word, or IDENT otherwise.
+func LookupIdent(ident string) TokenType {
+	if tok, ok := keywords[ident]; ok {
+		return tok
+	}
+	return IDENT
+}